@@ -1,13 +1,21 @@
 package core
 
 import (
+	"context"
 	"fmt"
+	"net/url"
+	"reflect"
 	"strings"
 	"time"
 )
 
 type DbType string
 
+const (
+	POSTGRES  DbType = "postgres"
+	GREENPLUM DbType = "greenplum"
+)
+
 type Uri struct {
 	DbType  DbType
 	Proto   string
@@ -17,11 +25,23 @@ type Uri struct {
 	User    string
 	Passwd  string
 	Charset string
+	Schema  string
 	Laddr   string
 	Raddr   string
 	Timeout time.Duration
 }
 
+// SetSchema sets the Postgres schema to use for this connection, trimming
+// surrounding whitespace. It is a no-op for any other DbType, since only
+// Postgres (and Postgres-derived dialects like Greenplum) expose schemas as
+// a namespace independent from the database.
+func (uri *Uri) SetSchema(schema string) {
+	if uri.DbType != POSTGRES && uri.DbType != GREENPLUM {
+		return
+	}
+	uri.Schema = strings.TrimSpace(schema)
+}
+
 // a dialect is a driver's wrapper
 type Dialect interface {
 	Init(*DB, *Uri, string, string) error
@@ -30,7 +50,16 @@ type Dialect interface {
 	DBType() DbType
 	SqlType(*Column) string
 
+	// DefaultSchema returns the schema that unqualified table names resolve
+	// to, or "" when the dialect has none configured (e.g. non-Postgres
+	// dialects, or Postgres with no Uri.Schema set).
+	DefaultSchema() string
+
+	// QuoteStr is deprecated in favor of Quoter; it remains for backward
+	// compatibility during the migration.
 	QuoteStr() string
+	Quoter() Quoter
+	SetQuotePolicy(policy QuotePolicy)
 	AndStr() string
 	OrStr() string
 	EqStr() string
@@ -42,8 +71,15 @@ type Dialect interface {
 	SupportCharset() bool
 	IndexOnTable() bool
 	ShowCreateNull() bool
+	// Features consolidates the capability flags above (plus
+	// AutoincrMode) into one value so builders don't need to call each
+	// flag method individually.
+	Features() *DialectFeatures
 
 	DropTableSql(tableName string) string
+	// IndexCheckSql, TableCheckSql and ColumnCheckSql take a bare
+	// tableName and resolve it against DefaultSchema() before querying; see
+	// the Base implementations.
 	IndexCheckSql(tableName, idxName string) (string, []interface{})
 	TableCheckSql(tableName string) (string, []interface{})
 	ColumnCheckSql(tableName, colName string, isPK bool) (string, []interface{})
@@ -58,6 +94,12 @@ type Dialect interface {
 
 	DriverName() string
 	DataSourceName() string
+
+	// Version probes the live connection q for the server's version.
+	Version(ctx context.Context, q Queryer) (*Version, error)
+	// ColumnTypeKind classifies a raw SQL type name, letting callers
+	// compare types across dialect-specific spellings (see Kind* consts).
+	ColumnTypeKind(sqlType string) int
 }
 
 func OpenDialect(dialect Dialect) (*DB, error) {
@@ -69,14 +111,39 @@ type Base struct {
 	dialect        Dialect
 	driverName     string
 	dataSourceName string
+	quotePolicy    QuotePolicy
+	autoincrMode   int
 	*Uri
 }
 
+// SetAutoincrMode changes which DDL strategy Features() reports for
+// autoincrementing columns. Dialects that use CREATE SEQUENCE/nextval()
+// instead of an inline column modifier (Oracle, Postgres-with-sequences)
+// should call this from their Init.
+func (b *Base) SetAutoincrMode(mode int) {
+	b.autoincrMode = mode
+}
+
 func (b *Base) DB() *DB {
 	return b.db
 }
 
+// Init stores db/uri on b. If uri is nil - a caller that only has a DSN and
+// wants the Dialect to parse it - it looks up the Driver registered for
+// drivername and parses dataSourceName through it, so dialects no longer
+// need to duplicate DSN parsing inside their own Init.
 func (b *Base) Init(db *DB, dialect Dialect, uri *Uri, drivername, dataSourceName string) error {
+	if uri == nil {
+		driver := QueryDriver(drivername)
+		if driver == nil {
+			return fmt.Errorf("core: no driver registered for %q, cannot parse DSN", drivername)
+		}
+		parsed, err := driver.Parse(drivername, dataSourceName)
+		if err != nil {
+			return err
+		}
+		uri = parsed
+	}
 	b.db, b.dialect, b.Uri = db, dialect, uri
 	b.driverName, b.dataSourceName = drivername, dataSourceName
 	return nil
@@ -90,6 +157,10 @@ func (b *Base) DBType() DbType {
 	return b.Uri.DbType
 }
 
+func (b *Base) DefaultSchema() string {
+	return b.Uri.Schema
+}
+
 func (b *Base) DriverName() string {
 	return b.driverName
 }
@@ -103,7 +174,18 @@ func (b *Base) DataSourceName() string {
 }
 
 func (b *Base) Quote(c string) string {
-	return b.dialect.QuoteStr() + c + b.dialect.QuoteStr()
+	switch b.quotePolicy {
+	case QuotePolicyNone:
+		return c
+	case QuotePolicyReserved:
+		q := b.dialect.Quoter()
+		if q.IsReserved == nil || !q.IsReserved(c) {
+			return c
+		}
+		return q.Quote(c)
+	default:
+		return b.dialect.Quoter().Quote(c)
+	}
 }
 
 func (b *Base) AndStr() string {
@@ -123,7 +205,95 @@ func (db *Base) RollBackStr() string {
 }
 
 func (db *Base) DropTableSql(tableName string) string {
-	return fmt.Sprintf("DROP TABLE IF EXISTS `%s`", tableName)
+	return fmt.Sprintf("DROP TABLE IF EXISTS %s", FullTableName(db.dialect, db.Quote, tableName))
+}
+
+// TableCheckSql reports whether tableName exists, resolved against the
+// dialect's DefaultSchema() rather than left to the connection's search
+// path/current database, so it agrees with the schema CreateTableSql and
+// friends generate DDL for.
+func (db *Base) TableCheckSql(tableName string) (string, []interface{}) {
+	schema := db.dialect.DefaultSchema()
+	tableName = TableNameNoSchema(tableName)
+	if schema == "" {
+		return "SELECT table_name FROM information_schema.tables WHERE table_name = ?",
+			[]interface{}{tableName}
+	}
+	return "SELECT table_name FROM information_schema.tables WHERE table_schema = ? AND table_name = ?",
+		[]interface{}{schema, tableName}
+}
+
+// ColumnCheckSql reports whether colName exists on tableName, resolved
+// against DefaultSchema() the same way TableCheckSql is. isPK is accepted
+// for dialects whose catalogs can narrow the check to primary-key columns
+// (e.g. by joining key_column_usage/table_constraints); the Base default
+// ignores it and just checks the column's existence.
+func (db *Base) ColumnCheckSql(tableName, colName string, isPK bool) (string, []interface{}) {
+	schema := db.dialect.DefaultSchema()
+	tableName = TableNameNoSchema(tableName)
+	if schema == "" {
+		return "SELECT column_name FROM information_schema.columns WHERE table_name = ? AND column_name = ?",
+			[]interface{}{tableName, colName}
+	}
+	return "SELECT column_name FROM information_schema.columns WHERE table_schema = ? AND table_name = ? AND column_name = ?",
+		[]interface{}{schema, tableName, colName}
+}
+
+// IndexCheckSql reports whether idxName exists on tableName, resolved
+// against DefaultSchema() the same way TableCheckSql is. information_schema
+// has no standard view for indexes, so this queries MySQL/MariaDB's
+// STATISTICS view; dialects on engines with a different catalog (Postgres's
+// pg_indexes, MSSQL's sys.indexes, ...) should override it.
+func (db *Base) IndexCheckSql(tableName, idxName string) (string, []interface{}) {
+	schema := db.dialect.DefaultSchema()
+	tableName = TableNameNoSchema(tableName)
+	if schema == "" {
+		return "SELECT index_name FROM information_schema.statistics WHERE table_name = ? AND index_name = ?",
+			[]interface{}{tableName, idxName}
+	}
+	return "SELECT index_name FROM information_schema.statistics WHERE table_schema = ? AND table_name = ? AND index_name = ?",
+		[]interface{}{schema, tableName, idxName}
+}
+
+// TableNameNoSchema strips a leading "schema." prefix from tableName, if
+// present, returning the bare table name.
+func TableNameNoSchema(tableName string) string {
+	if idx := strings.Index(tableName, "."); idx >= 0 {
+		return tableName[idx+1:]
+	}
+	return tableName
+}
+
+// TableNameWithSchema prefixes tableName with dialect's default schema
+// (schema.table) when one is configured and tableName isn't already
+// schema-qualified. It leaves tableName untouched otherwise.
+func TableNameWithSchema(dialect Dialect, tableName string) string {
+	schema := dialect.DefaultSchema()
+	if schema == "" || strings.Contains(tableName, ".") {
+		return tableName
+	}
+	return schema + "." + tableName
+}
+
+// FullTableName returns the schema-qualified table name with each part
+// quoted individually via quote, ready to be embedded in generated SQL.
+func FullTableName(dialect Dialect, quote func(string) string, tableName string) string {
+	schema := dialect.DefaultSchema()
+	if schema == "" {
+		return quote(TableNameNoSchema(tableName))
+	}
+	return quote(schema) + "." + quote(TableNameNoSchema(tableName))
+}
+
+// QueryDefaultPostgresSchema asks a live Postgres connection for its
+// current_schema(), so callers can seed Uri.Schema without requiring the
+// user to configure it explicitly.
+func QueryDefaultPostgresSchema(db *DB) (string, error) {
+	var schema string
+	if err := db.QueryRow("SELECT current_schema()").Scan(&schema); err != nil {
+		return "", err
+	}
+	return schema, nil
 }
 
 func (db *Base) CreateIndexSql(tableName string, index *Index) string {
@@ -137,7 +307,7 @@ func (db *Base) CreateIndexSql(tableName string, index *Index) string {
 		idxName = fmt.Sprintf("IDX_%v_%v", tableName, index.Name)
 	}
 	return fmt.Sprintf("CREATE%s INDEX %v ON %v (%v);", unique,
-		quote(idxName), quote(tableName),
+		quote(idxName), FullTableName(db.dialect, quote, tableName),
 		quote(strings.Join(index.Cols, quote(","))))
 }
 
@@ -148,18 +318,27 @@ func (b *Base) CreateTableSql(table *Table, tableName, storeEngine, charset stri
 		tableName = table.Name
 	}
 
-	sql += b.Quote(tableName) + " ("
+	var seqSql string
+	features := b.dialect.Features()
+
+	sql += FullTableName(b.dialect, b.Quote, tableName) + " ("
 
 	pkList := table.PrimaryKeys
 
 	for _, colName := range table.ColumnsSeq() {
 		col := table.GetColumn(colName)
+		var colSql string
 		if col.IsPrimaryKey && len(pkList) == 1 {
-			sql += col.String(b.dialect)
+			colSql = col.String(b.dialect)
 		} else {
-			sql += col.StringNoPk(b.dialect)
+			colSql = col.StringNoPk(b.dialect)
 		}
-		sql = strings.TrimSpace(sql)
+		if col.IsAutoIncrement && features.AutoincrMode == SequenceAutoincrMode {
+			seqIdent, seqLiteral := sequenceIdentifier(b.dialect, b.Quote, tableName, colName)
+			seqSql += fmt.Sprintf("CREATE SEQUENCE IF NOT EXISTS %s; ", seqIdent)
+			colSql = strings.TrimSpace(colSql) + fmt.Sprintf(" DEFAULT nextval('%s')", seqLiteral)
+		}
+		sql += strings.TrimSpace(colSql)
 		sql += ", "
 	}
 
@@ -170,10 +349,10 @@ func (b *Base) CreateTableSql(table *Table, tableName, storeEngine, charset stri
 	}
 
 	sql = sql[:len(sql)-2] + ")"
-	if b.dialect.SupportEngine() && storeEngine != "" {
+	if features.SupportEngine && storeEngine != "" {
 		sql += " ENGINE=" + storeEngine
 	}
-	if b.dialect.SupportCharset() {
+	if features.SupportCharset {
 		if len(charset) == 0 {
 			charset = b.dialect.URI().Charset
 		}
@@ -182,20 +361,134 @@ func (b *Base) CreateTableSql(table *Table, tableName, storeEngine, charset stri
 		}
 	}
 	sql += ";"
-	return sql
+	return seqSql + sql
 }
 
 var (
-	dialects = map[DbType]Dialect{}
+	dialects = map[DbType]func() Dialect{}
 )
 
-func RegisterDialect(dbName DbType, dialect Dialect) {
-	if dialect == nil {
-		panic("core: Register dialect is nil")
+// RegisterDialect registers a factory that produces a fresh Dialect
+// instance for dbName. Using a factory instead of a shared instance means
+// QueryDialect hands each *DB its own Dialect, so Init-mutated state (Uri,
+// quote policy, ...) on one connection can't leak into another.
+func RegisterDialect(dbName DbType, factory func() Dialect) {
+	if factory == nil {
+		panic("core: Register dialect factory is nil")
 	}
-	dialects[dbName] = dialect // !nashtsai! allow override dialect
+	dialects[dbName] = factory // !nashtsai! allow override dialect
 }
 
+// QueryDialect returns a fresh Dialect instance for dbName, or nil if no
+// dialect was registered under that name.
 func QueryDialect(dbName DbType) Dialect {
-	return dialects[dbName]
+	factory, ok := dialects[dbName]
+	if !ok {
+		return nil
+	}
+	return factory()
+}
+
+// Instance adapts a single, already-constructed Dialect instance into a
+// factory function for RegisterDialect, for callers migrating from the old
+// RegisterDialect(DbType, Dialect) signature: RegisterDialect(name,
+// Instance(oldDialectValue)). Each call to the returned factory allocates a
+// fresh zero-value instance of dialect's underlying type, so state doesn't
+// leak between callers the way it would by reusing dialect directly.
+func Instance(dialect Dialect) func() Dialect {
+	t := reflect.TypeOf(dialect)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return func() Dialect {
+		return reflect.New(t).Interface().(Dialect)
+	}
+}
+
+// Driver extracts connection information from a driver-specific DSN,
+// independent of opening an actual connection.
+type Driver interface {
+	Parse(driverName, dataSourceName string) (*Uri, error)
+}
+
+var drivers = map[string]Driver{}
+
+// RegisterDriver registers the Driver used to parse DSNs for driverName.
+func RegisterDriver(driverName string, driver Driver) {
+	if driver == nil {
+		panic("core: Register driver is nil")
+	}
+	drivers[driverName] = driver
+}
+
+// QueryDriver returns the Driver registered for driverName, or nil if none
+// was registered.
+func QueryDriver(driverName string) Driver {
+	return drivers[driverName]
+}
+
+// postgresDriver parses Postgres DSNs into a Uri without opening a
+// connection, either a "postgres://"/"postgresql://" URL or libpq's
+// keyword=value format ("host=localhost user=foo dbname=bar"). Greenplum
+// registers under the same driver name (see greenplum.DriverName) since it
+// speaks the same wire protocol and DSN format.
+type postgresDriver struct{}
+
+func init() {
+	RegisterDriver("postgres", &postgresDriver{})
+}
+
+func (p *postgresDriver) Parse(driverName, dataSourceName string) (*Uri, error) {
+	if strings.HasPrefix(dataSourceName, "postgres://") || strings.HasPrefix(dataSourceName, "postgresql://") {
+		return p.parseURL(dataSourceName)
+	}
+	return p.parseKeywordValue(dataSourceName)
+}
+
+func (p *postgresDriver) parseURL(dataSourceName string) (*Uri, error) {
+	u, err := url.Parse(dataSourceName)
+	if err != nil {
+		return nil, fmt.Errorf("core: invalid postgres DSN: %w", err)
+	}
+	uri := &Uri{
+		DbType: POSTGRES,
+		Proto:  "tcp",
+		Host:   u.Hostname(),
+		Port:   u.Port(),
+		DbName: strings.TrimPrefix(u.Path, "/"),
+	}
+	if u.User != nil {
+		uri.User = u.User.Username()
+		uri.Passwd, _ = u.User.Password()
+	}
+	if schema := u.Query().Get("search_path"); schema != "" {
+		uri.SetSchema(schema)
+	}
+	return uri, nil
+}
+
+func (p *postgresDriver) parseKeywordValue(dataSourceName string) (*Uri, error) {
+	uri := &Uri{DbType: POSTGRES, Proto: "tcp"}
+	for _, field := range strings.Fields(dataSourceName) {
+		key, value, ok := strings.Cut(field, "=")
+		if !ok {
+			return nil, fmt.Errorf("core: invalid postgres DSN field %q", field)
+		}
+		value = strings.Trim(value, `'"`)
+		switch key {
+		case "host":
+			uri.Host = value
+		case "port":
+			uri.Port = value
+		case "user":
+			uri.User = value
+		case "password":
+			uri.Passwd = value
+		case "dbname":
+			uri.DbName = value
+		case "search_path":
+			uri.SetSchema(value)
+		}
+	}
+	return uri, nil
 }
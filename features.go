@@ -0,0 +1,67 @@
+package core
+
+import "fmt"
+
+// Autoincrement strategies a dialect can use for a primary key column.
+const (
+	// IncrAutoincrMode relies on the column definition itself, e.g. MySQL's
+	// AUTO_INCREMENT, SQLite's AUTOINCREMENT or Postgres's SERIAL type.
+	IncrAutoincrMode = iota
+	// SequenceAutoincrMode relies on a separate CREATE SEQUENCE plus a
+	// nextval() default, as used by Oracle and (optionally) Postgres.
+	SequenceAutoincrMode
+)
+
+// DialectFeatures collects the capability flags builders in Base need to
+// generate correct DDL, replacing the previously scattered
+// SupportInsertMany/SupportEngine/... methods with a single value dialects
+// can construct once in Init.
+type DialectFeatures struct {
+	AutoincrMode      int
+	SupportInsertMany bool
+	SupportEngine     bool
+	SupportCharset    bool
+	IndexOnTable      bool
+	ShowCreateNull    bool
+}
+
+// Features returns the Base default feature set, built from the existing
+// per-method capability flags so dialects that haven't migrated to
+// overriding Features directly keep working unchanged. AutoincrMode
+// defaults to IncrAutoincrMode; call SetAutoincrMode to opt into
+// SequenceAutoincrMode.
+func (b *Base) Features() *DialectFeatures {
+	return &DialectFeatures{
+		AutoincrMode:      b.autoincrMode,
+		SupportInsertMany: b.dialect.SupportInsertMany(),
+		SupportEngine:     b.dialect.SupportEngine(),
+		SupportCharset:    b.dialect.SupportCharset(),
+		IndexOnTable:      b.dialect.IndexOnTable(),
+		ShowCreateNull:    b.dialect.ShowCreateNull(),
+	}
+}
+
+// SequenceName returns the conventional name of the sequence backing an
+// autoincrementing column, for dialects whose Features().AutoincrMode is
+// SequenceAutoincrMode. table is a bare, schema-unqualified table name; see
+// sequenceIdentifier for schema-qualified identifiers/literals.
+func SequenceName(table, col string) string {
+	return fmt.Sprintf("%s_%s_seq", table, col)
+}
+
+// sequenceIdentifier returns the quoted, schema-qualified identifier to use
+// in a CREATE SEQUENCE statement and the bare (unquoted) literal to embed
+// in nextval('...'), qualifying both with dialect.DefaultSchema() the same
+// way FullTableName qualifies a table name - so two schemas creating the
+// same table name don't collide on, or silently share, one default-schema
+// sequence.
+func sequenceIdentifier(dialect Dialect, quote func(string) string, tableName, colName string) (ident, literal string) {
+	schema := dialect.DefaultSchema()
+	seqName := SequenceName(TableNameNoSchema(tableName), colName)
+	ident, literal = quote(seqName), seqName
+	if schema != "" {
+		ident = quote(schema) + "." + ident
+		literal = schema + "." + literal
+	}
+	return ident, literal
+}
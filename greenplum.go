@@ -0,0 +1,72 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+)
+
+// greenplum is built on top of postgres: Greenplum speaks the Postgres wire
+// protocol and SQL dialect, so it inherits postgres's quoting, schema
+// handling, driver/DSN format and catalog introspection (GetColumns/
+// GetTables/GetIndexes/IndexCheckSql) wholesale, and only overrides the
+// handful of places GP's DDL actually diverges: every CREATE TABLE must
+// carry a DISTRIBUTED BY/DISTRIBUTED RANDOMLY clause telling the segments
+// how to shard rows, and a few Postgres type aliases aren't available.
+// It is registered under GREENPLUM in init below, so users migrating
+// existing Postgres-tagged models only need to select that dialect; the
+// driver and DSN stay the same.
+type greenplum struct {
+	postgres
+}
+
+func init() {
+	RegisterDialect(GREENPLUM, func() Dialect { return &greenplum{} })
+}
+
+// Init stores db/uri via Base.Init directly rather than postgres.Init,
+// since postgres.Init would register the embedded postgres value - not db
+// - as the self-dispatching dialect, bypassing Greenplum's overrides. It
+// then opts into SequenceAutoincrMode: Greenplum discourages SERIAL/
+// BIGSERIAL on columns that double as a distribution key, so
+// autoincrementing columns get an explicit CREATE SEQUENCE + DEFAULT
+// nextval() instead (see Base.CreateTableSql).
+func (db *greenplum) Init(d *DB, uri *Uri, driverName, dataSourceName string) error {
+	if err := db.Base.Init(d, db, uri, driverName, dataSourceName); err != nil {
+		return err
+	}
+	db.SetAutoincrMode(SequenceAutoincrMode)
+	return nil
+}
+
+// CreateTableSql generates the same DDL as postgres, except that
+// autoincrementing columns use a sequence (see Init), then appends the
+// DISTRIBUTED BY (...) clause Greenplum requires on every CREATE TABLE,
+// derived from the table's primary key list, falling back to DISTRIBUTED
+// RANDOMLY when the table has none.
+func (db *greenplum) CreateTableSql(table *Table, tableName, storeEngine, charset string) string {
+	sql := strings.TrimSuffix(db.Base.CreateTableSql(table, tableName, storeEngine, charset), ";")
+	return sql + distributedByClause(db.Quote, table.PrimaryKeys) + ";"
+}
+
+// distributedByClause renders Greenplum's DISTRIBUTED BY (...) clause from
+// a table's primary key list, falling back to DISTRIBUTED RANDOMLY when
+// there is none.
+func distributedByClause(quote func(string) string, pkList []string) string {
+	if len(pkList) == 0 {
+		return " DISTRIBUTED RANDOMLY"
+	}
+	return fmt.Sprintf(" DISTRIBUTED BY (%s)", quote(strings.Join(pkList, quote(","))))
+}
+
+// SqlType maps JSONB, which most Greenplum releases don't support
+// natively, to JSON; it is the only type remapped here. Everything else
+// goes through postgres's mapping (and its length/precision handling). Unlike postgres,
+// autoincrementing integers stay plain INTEGER/BIGINT: the SERIAL/
+// BIGSERIAL default is skipped in favor of the CREATE SEQUENCE + DEFAULT
+// nextval() that Init's SequenceAutoincrMode adds in Base.CreateTableSql.
+func (db *greenplum) SqlType(c *Column) string {
+	if c.SQLType.Name == "JSONB" {
+		return withLengthSql("JSON", c)
+	}
+	return withLengthSql(postgresTypeName(c.SQLType.Name), c)
+}
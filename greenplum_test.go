@@ -0,0 +1,39 @@
+package core
+
+import "testing"
+
+func TestGreenplumSqlType(t *testing.T) {
+	gp := &greenplum{}
+
+	cases := []struct {
+		name string
+		col  *Column
+		want string
+	}{
+		{"integer", &Column{SQLType: SQLType{Name: Integer}}, "INTEGER"},
+		{"autoincrement bigint stays plain (sequence handles it)", &Column{SQLType: SQLType{Name: BigInt}, IsAutoIncrement: true}, "BIGINT"},
+		{"varchar keeps length", &Column{SQLType: SQLType{Name: Varchar}, Length: 255}, "VARCHAR(255)"},
+		{"numeric keeps precision and scale", &Column{SQLType: SQLType{Name: Numeric}, Length: 10, Length2: 2}, "NUMERIC(10,2)"},
+		{"jsonb falls back to json", &Column{SQLType: SQLType{Name: "JSONB"}}, "JSON"},
+	}
+	for _, c := range cases {
+		if got := gp.SqlType(c.col); got != c.want {
+			t.Errorf("%s: SqlType = %q, want %q", c.name, got, c.want)
+		}
+	}
+}
+
+func TestDistributedByClause(t *testing.T) {
+	pg := &postgres{}
+	pg.dialect = pg
+
+	if got, want := distributedByClause(pg.Quote, nil), " DISTRIBUTED RANDOMLY"; got != want {
+		t.Errorf("no primary key: %q, want %q", got, want)
+	}
+	if got, want := distributedByClause(pg.Quote, []string{"id"}), ` DISTRIBUTED BY ("id")`; got != want {
+		t.Errorf("single-column PK: %q, want %q", got, want)
+	}
+	if got, want := distributedByClause(pg.Quote, []string{"tenant_id", "id"}), ` DISTRIBUTED BY ("tenant_id","id")`; got != want {
+		t.Errorf("composite PK: %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,290 @@
+package core
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// postgres implements Dialect for PostgreSQL. Greenplum (greenplum.go)
+// embeds this and overrides only the handful of places its MPP DDL
+// diverges, rather than duplicating schema introspection from scratch.
+type postgres struct {
+	Base
+}
+
+func init() {
+	RegisterDialect(POSTGRES, func() Dialect { return &postgres{} })
+}
+
+// Init stores db/uri on the embedded Base, passing itself through so Base's
+// generic SQL builders call back into postgres's overrides.
+func (db *postgres) Init(d *DB, uri *Uri, driverName, dataSourceName string) error {
+	return db.Base.Init(d, db, uri, driverName, dataSourceName)
+}
+
+func (db *postgres) Quoter() Quoter {
+	return Quoter{Prefix: '"', Suffix: '"'}
+}
+
+// DriverName reports "postgres" regardless of the registered dialect name,
+// since Greenplum and Postgres are queried through the same driver.
+func (db *postgres) DriverName() string {
+	return "postgres"
+}
+
+func (db *postgres) SupportEngine() bool {
+	return false
+}
+
+func (db *postgres) SupportCharset() bool {
+	return false
+}
+
+func (db *postgres) IndexOnTable() bool {
+	return false
+}
+
+// AutoIncrStr is empty: Postgres autoincrements via a SERIAL/BIGSERIAL
+// column type (see SqlType), not an inline modifier like MySQL's
+// AUTO_INCREMENT.
+func (db *postgres) AutoIncrStr() string {
+	return ""
+}
+
+func (db *postgres) SupportInsertMany() bool {
+	return true
+}
+
+// postgresTypeName maps a Column's generic SQLType.Name to its Postgres
+// spelling; shared by postgres.SqlType and greenplum.SqlType so the two
+// only diverge where Greenplum genuinely needs to.
+func postgresTypeName(t string) string {
+	switch t {
+	case TinyInt:
+		return "SMALLINT"
+	case MediumInt, Int, Integer:
+		return "INTEGER"
+	case BigInt:
+		return "BIGINT"
+	case Bool, Boolean:
+		return "BOOLEAN"
+	case Blob, TinyBlob, MediumBlob, LongBlob:
+		return "BYTEA"
+	case Double:
+		return "DOUBLE PRECISION"
+	default:
+		return t
+	}
+}
+
+// withLengthSql reattaches a column's length/precision to its mapped type
+// name, e.g. "VARCHAR" + Length 255 -> "VARCHAR(255)", "NUMERIC" + Length
+// 10, Length2 2 -> "NUMERIC(10,2)".
+func withLengthSql(name string, c *Column) string {
+	switch {
+	case c.Length2 > 0:
+		return fmt.Sprintf("%s(%d,%d)", name, c.Length, c.Length2)
+	case c.Length > 0:
+		return fmt.Sprintf("%s(%d)", name, c.Length)
+	default:
+		return name
+	}
+}
+
+// SqlType maps c to its Postgres spelling, using SERIAL/BIGSERIAL for
+// autoincrementing integers under the default IncrAutoincrMode.
+func (db *postgres) SqlType(c *Column) string {
+	if c.IsAutoIncrement && db.Features().AutoincrMode == IncrAutoincrMode {
+		switch c.SQLType.Name {
+		case BigInt:
+			return "BIGSERIAL"
+		case MediumInt, Int, Integer:
+			return "SERIAL"
+		}
+	}
+	return withLengthSql(postgresTypeName(c.SQLType.Name), c)
+}
+
+// IndexCheckSql overrides Base's information_schema.statistics default -
+// Postgres has no such view - with a query against pg_indexes, resolved
+// against DefaultSchema() the same way Base's TableCheckSql/ColumnCheckSql
+// are.
+func (db *postgres) IndexCheckSql(tableName, idxName string) (string, []interface{}) {
+	schema := db.DefaultSchema()
+	tableName = TableNameNoSchema(tableName)
+	if schema == "" {
+		return "SELECT indexname FROM pg_indexes WHERE tablename = $1 AND indexname = $2",
+			[]interface{}{tableName, idxName}
+	}
+	return "SELECT indexname FROM pg_indexes WHERE schemaname = $1 AND tablename = $2 AND indexname = $3",
+		[]interface{}{schema, tableName, idxName}
+}
+
+// GetColumns lists tableName's columns, in ordinal order, by querying
+// information_schema.columns under DefaultSchema() (defaulting to
+// "public", Postgres's default schema, when none is configured).
+func (db *postgres) GetColumns(tableName string) ([]string, map[string]*Column, error) {
+	schema := db.DefaultSchema()
+	if schema == "" {
+		schema = "public"
+	}
+
+	rows, err := db.DB().Query(
+		`SELECT column_name, data_type, character_maximum_length, numeric_precision, numeric_scale,
+			is_nullable, column_default
+		FROM information_schema.columns
+		WHERE table_schema = $1 AND table_name = $2
+		ORDER BY ordinal_position`,
+		schema, TableNameNoSchema(tableName))
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	var colSeq []string
+	cols := make(map[string]*Column)
+	for rows.Next() {
+		var name, dataType, nullable string
+		var length, precision, scale sql.NullInt64
+		var def sql.NullString
+		if err := rows.Scan(&name, &dataType, &length, &precision, &scale, &nullable, &def); err != nil {
+			return nil, nil, err
+		}
+
+		col := &Column{
+			Name:     name,
+			SQLType:  SQLType{Name: strings.ToUpper(dataType)},
+			Nullable: nullable == "YES",
+		}
+		switch {
+		case length.Valid:
+			col.Length = int(length.Int64)
+		case precision.Valid:
+			col.Length = int(precision.Int64)
+			col.Length2 = int(scale.Int64)
+		}
+		if def.Valid {
+			col.Default = def.String
+			col.IsAutoIncrement = strings.HasPrefix(def.String, "nextval(")
+		}
+
+		colSeq = append(colSeq, name)
+		cols[name] = col
+	}
+	return colSeq, cols, rows.Err()
+}
+
+// GetTables lists the base tables in DefaultSchema() (defaulting to
+// "public"), qualifying each name with its schema via TableNameWithSchema
+// so callers juggling more than one schema don't collide on bare names.
+func (db *postgres) GetTables() ([]*Table, error) {
+	schema := db.DefaultSchema()
+	if schema == "" {
+		schema = "public"
+	}
+
+	rows, err := db.DB().Query(
+		`SELECT table_name FROM information_schema.tables
+		WHERE table_schema = $1 AND table_type = 'BASE TABLE'`,
+		schema)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tables []*Table
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		tables = append(tables, &Table{Name: TableNameWithSchema(db, name)})
+	}
+	return tables, rows.Err()
+}
+
+// GetIndexes lists tableName's indexes, keyed by name, via pg_indexes.
+func (db *postgres) GetIndexes(tableName string) (map[string]*Index, error) {
+	schema := db.DefaultSchema()
+	if schema == "" {
+		schema = "public"
+	}
+
+	rows, err := db.DB().Query(
+		`SELECT indexname, indexdef FROM pg_indexes WHERE schemaname = $1 AND tablename = $2`,
+		schema, TableNameNoSchema(tableName))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	indexes := make(map[string]*Index)
+	for rows.Next() {
+		var name, def string
+		if err := rows.Scan(&name, &def); err != nil {
+			return nil, err
+		}
+		idx := &Index{Name: name, Type: IndexType}
+		if strings.Contains(def, " UNIQUE ") {
+			idx.Type = UniqueType
+		}
+		indexes[name] = idx
+	}
+	return indexes, rows.Err()
+}
+
+func (db *postgres) Filters() []Filter {
+	return []Filter{}
+}
+
+// Version probes the server via SHOW server_version, e.g. "14.5 (Debian
+// 14.5-1)" or Greenplum's "9.4.24 (Greenplum Database 6.19.0 build ...)" -
+// the leading dotted number becomes Major/Minor/Number, anything after the
+// first space becomes Edition.
+func (db *postgres) Version(ctx context.Context, q Queryer) (*Version, error) {
+	rows, err := q.QueryContext(ctx, "SHOW server_version")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return nil, fmt.Errorf("core: SHOW server_version returned no rows")
+	}
+	var raw string
+	if err := rows.Scan(&raw); err != nil {
+		return nil, err
+	}
+
+	number, edition := raw, ""
+	if idx := strings.Index(raw, " "); idx >= 0 {
+		number, edition = raw[:idx], strings.TrimSpace(raw[idx+1:])
+	}
+
+	parts := strings.SplitN(number, ".", 3)
+	major, _ := strconv.Atoi(parts[0])
+	var minor int
+	if len(parts) > 1 {
+		minor, _ = strconv.Atoi(parts[1])
+	}
+
+	return &Version{Number: number, Major: major, Minor: minor, Edition: edition}, nil
+}
+
+// ColumnTypeKind overrides Base's ANSI default for Postgres-only type
+// names it wouldn't otherwise recognize.
+func (db *postgres) ColumnTypeKind(sqlType string) int {
+	switch strings.ToUpper(sqlType) {
+	case "UUID", "INET", "CIDR", "MACADDR", "XML":
+		return KindText
+	case "MONEY":
+		return KindNumeric
+	case "INTERVAL":
+		return KindTime
+	default:
+		return db.Base.ColumnTypeKind(sqlType)
+	}
+}
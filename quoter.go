@@ -0,0 +1,55 @@
+package core
+
+// Quoter describes how a dialect wraps an identifier to make it safe to use
+// verbatim in generated SQL. Prefix and Suffix are the opening and closing
+// quote characters (e.g. '`'/'`' for MySQL, '['/']' for MSSQL, '"'/'"' for
+// Postgres/Oracle), and IsReserved, when set, reports whether an identifier
+// needs quoting at all under QuotePolicyReserved.
+type Quoter struct {
+	Prefix, Suffix byte
+	IsReserved     func(string) bool
+}
+
+// Quote wraps s in the quoter's prefix/suffix. An empty s is returned
+// unchanged.
+func (q Quoter) Quote(s string) string {
+	if s == "" {
+		return s
+	}
+	return string(q.Prefix) + s + string(q.Suffix)
+}
+
+// QuotePolicy controls when Base.Quote actually quotes an identifier.
+type QuotePolicy int
+
+const (
+	// QuotePolicyAlways quotes every identifier. This is the default and
+	// matches the historical, always-quote behavior.
+	QuotePolicyAlways QuotePolicy = iota
+	// QuotePolicyNone never quotes identifiers.
+	QuotePolicyNone
+	// QuotePolicyReserved only quotes identifiers that collide with the
+	// dialect's reserved words, as reported by Quoter.IsReserved.
+	QuotePolicyReserved
+)
+
+// SetQuotePolicy changes how Base.Quote decides whether to quote an
+// identifier.
+func (b *Base) SetQuotePolicy(policy QuotePolicy) {
+	b.quotePolicy = policy
+}
+
+// Quoter returns the dialect's quote characters. The Base default is
+// backtick-quoting (MySQL/SQLite style); dialects with asymmetric or
+// different quote characters (MSSQL, Oracle, Postgres) override it.
+func (b *Base) Quoter() Quoter {
+	return Quoter{Prefix: '`', Suffix: '`'}
+}
+
+// QuoteStr is kept as a thin backward-compatible shim over Quoter for
+// callers that assume a single, symmetric quote character. New code should
+// use Quoter instead; this will be removed once dialects have migrated off
+// it.
+func (b *Base) QuoteStr() string {
+	return string(b.dialect.Quoter().Prefix)
+}
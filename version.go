@@ -0,0 +1,73 @@
+package core
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// Queryer is the minimal query surface Dialect.Version needs to run its
+// probe statement; *DB satisfies it.
+type Queryer interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// Version describes a database server's reported version, split into the
+// pieces dialects commonly need to gate feature detection on (e.g. "JSONB
+// requires Postgres >= 9.4").
+type Version struct {
+	Number  string
+	Major   int
+	Minor   int
+	Edition string
+}
+
+// Version runs the dialect's version probe against q and parses the
+// result. The Base implementation is unimplemented since there's no
+// dialect-agnostic version query; concrete dialects override it.
+func (b *Base) Version(ctx context.Context, q Queryer) (*Version, error) {
+	return nil, fmt.Errorf("core: Version not implemented for dialect %q", b.Uri.DbType)
+}
+
+// Column type classification, used to decide whether two SQL type spellings
+// describe the same underlying kind of data (e.g. VARCHAR(255) and TEXT are
+// both KindText) without needing an exact string match.
+const (
+	KindUnknown = iota
+	KindText
+	KindNumeric
+	KindTime
+	KindBool
+	KindBlob
+	KindJSON
+	KindArray
+)
+
+// ColumnTypeKind classifies a raw SQL type name into one of the Kind*
+// constants. The Base implementation covers common ANSI type names by
+// substring (so JSONB is KindJSON and NVARCHAR is KindText); dialects
+// override it for their own aliases (e.g. Postgres's UUID or INTERVAL).
+func (b *Base) ColumnTypeKind(sqlType string) int {
+	t := strings.ToUpper(sqlType)
+	switch {
+	case strings.Contains(t, "JSON"):
+		return KindJSON
+	case strings.Contains(t, "ARRAY"), strings.HasSuffix(t, "[]"):
+		return KindArray
+	case strings.Contains(t, "BOOL"):
+		return KindBool
+	case strings.Contains(t, "BLOB"), strings.Contains(t, "BINARY"), strings.Contains(t, "BYTEA"):
+		return KindBlob
+	case strings.Contains(t, "DATE"), strings.Contains(t, "TIME"):
+		return KindTime
+	case strings.Contains(t, "CHAR"), strings.Contains(t, "TEXT"), strings.Contains(t, "CLOB"):
+		return KindText
+	case strings.Contains(t, "INT"), strings.Contains(t, "DECIMAL"), strings.Contains(t, "NUMERIC"),
+		strings.Contains(t, "FLOAT"), strings.Contains(t, "DOUBLE"), strings.Contains(t, "REAL"),
+		strings.Contains(t, "SERIAL"):
+		return KindNumeric
+	default:
+		return KindUnknown
+	}
+}